@@ -0,0 +1,160 @@
+/*
+ * go-libdeluge v0.1.0 - a native deluge RPC client library
+ * Copyright (C) 2015~2016 gdm85 - https://github.com/gdm85/go-libdeluge/
+This program is free software; you can redistribute it and/or
+modify it under the terms of the GNU General Public License
+as published by the Free Software Foundation; either version 2
+of the License, or (at your option) any later version.
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+You should have received a copy of the GNU General Public License
+along with this program; if not, write to the Free Software
+Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+*/
+package delugeclient
+
+import (
+	"context"
+
+	"github.com/gdm85/go-rencode"
+)
+
+// blocklistPluginName is the plugin name as reported by
+// daemon.get_available_plugins and expected by core.enable_plugin.
+const blocklistPluginName = "Blocklist"
+
+// BlocklistPlugin wraps the Blocklist plugin's RPCs, which manage a list of
+// blocked IP ranges analogous to anacrolix/torrent's iplist blocklist.
+type BlocklistPlugin struct {
+	*Plugin
+}
+
+// BlocklistPlugin returns a handle to the Blocklist plugin.
+func (c *Client) BlocklistPlugin() *BlocklistPlugin {
+	return &BlocklistPlugin{Plugin: c.Plugin(blocklistPluginName)}
+}
+
+// BlocklistStatus reports the daemon's current view of its imported
+// blocklist, as returned by blocklist.get_status.
+type BlocklistStatus struct {
+	State      string
+	FileType   string
+	URL        string
+	NumEntries int64
+	Timestamp  int64
+}
+
+// Status fetches the daemon's current blocklist state.
+func (b *BlocklistPlugin) Status() (BlocklistStatus, error) {
+	ctx, cancel := b.client.WithTimeout()
+	defer cancel()
+	return b.StatusContext(ctx)
+}
+
+func (b *BlocklistPlugin) StatusContext(ctx context.Context) (BlocklistStatus, error) {
+	resp, err := b.client.RpcContext(ctx, "blocklist.get_status", rencode.List{}, rencode.Dictionary{})
+	if err != nil {
+		return BlocklistStatus{}, err
+	}
+	if resp.IsError() {
+		return BlocklistStatus{}, RPCError{resp.String()}
+	}
+
+	var statusDict rencode.Dictionary
+	err = resp.returnValue.Scan(&statusDict)
+	if err != nil {
+		return BlocklistStatus{}, err
+	}
+
+	var status BlocklistStatus
+	for i := 0; i < statusDict.Length(); i++ {
+		key := string(statusDict.Keys()[i].([]byte))
+		value := statusDict.Values()[i]
+		switch key {
+		case "state":
+			status.State, _ = toString(value)
+		case "file_type":
+			status.FileType, _ = toString(value)
+		case "url":
+			status.URL, _ = toString(value)
+		case "num_blocked":
+			status.NumEntries, _ = toInt64(value)
+		case "timestamp":
+			status.Timestamp, _ = toInt64(value)
+		}
+	}
+
+	return status, nil
+}
+
+// CheckImport reports whether the configured blocklist URL has a newer
+// list available than the one currently imported.
+func (b *BlocklistPlugin) CheckImport() (bool, error) {
+	ctx, cancel := b.client.WithTimeout()
+	defer cancel()
+	return b.CheckImportContext(ctx)
+}
+
+func (b *BlocklistPlugin) CheckImportContext(ctx context.Context) (bool, error) {
+	resp, err := b.client.RpcContext(ctx, "blocklist.check_import", rencode.List{}, rencode.Dictionary{})
+	if err != nil {
+		return false, err
+	}
+	if resp.IsError() {
+		return false, RPCError{resp.String()}
+	}
+
+	var needsImport bool
+	err = resp.returnValue.Scan(&needsImport)
+	if err != nil {
+		return false, err
+	}
+
+	return needsImport, nil
+}
+
+// ForceImport re-downloads and imports the blocklist from the configured
+// URL, regardless of whether CheckImport says it is needed.
+func (b *BlocklistPlugin) ForceImport() error {
+	ctx, cancel := b.client.WithTimeout()
+	defer cancel()
+	return b.ForceImportContext(ctx)
+}
+
+func (b *BlocklistPlugin) ForceImportContext(ctx context.Context) error {
+	resp, err := b.client.RpcContext(ctx, "blocklist.force_import", rencode.List{}, rencode.Dictionary{})
+	if err != nil {
+		return err
+	}
+	if resp.IsError() {
+		return RPCError{resp.String()}
+	}
+	return nil
+}
+
+// SetURL points the daemon at a new blocklist URL, to be fetched on the
+// next import.
+func (b *BlocklistPlugin) SetURL(url string) error {
+	ctx, cancel := b.client.WithTimeout()
+	defer cancel()
+	return b.SetURLContext(ctx, url)
+}
+
+func (b *BlocklistPlugin) SetURLContext(ctx context.Context, url string) error {
+	var config rencode.Dictionary
+	config.Add("url", url)
+
+	var args rencode.List
+	args.Add(config)
+
+	resp, err := b.client.RpcContext(ctx, "blocklist.set_config", args, rencode.Dictionary{})
+	if err != nil {
+		return err
+	}
+	if resp.IsError() {
+		return RPCError{resp.String()}
+	}
+	return nil
+}
@@ -18,11 +18,17 @@ package delugeclient
 import (
 	"bytes"
 	"compress/zlib"
+	"context"
 	"crypto/tls"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"log"
 	"math"
+	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/gdm85/go-rencode"
@@ -53,6 +59,52 @@ type Client struct {
 	conn     *tls.Conn
 	serial   int64
 	classID  int64
+
+	// writeMu serializes access to the connection and to serial
+	// generation; everything else about a call is concurrent.
+	writeMu sync.Mutex
+
+	// pending holds the call a caller is waiting on, keyed by serial, so
+	// the read loop can route a response back to its caller.
+	pendingMu sync.Mutex
+	pending   map[int64]*pendingCall
+
+	events *eventBus
+	stats  *clientStats
+
+	// state is a ClientState, accessed atomically so Run's goroutine and
+	// arbitrary caller goroutines can both read it without a lock.
+	state int32
+
+	// readyCh is closed by setState each time the client transitions to
+	// Ready, and replaced with a fresh channel for the next transition.
+	readyMu sync.Mutex
+	readyCh chan struct{}
+
+	// connMu/connDone/connGen identify the current connection attempt:
+	// connDone is closed by the read loop when that connection drops,
+	// which is also the signal RpcContext waits on to fail pending calls
+	// with ErrDisconnected instead of hanging. connGen is bumped every
+	// time conn is replaced, so a call dispatched against an older
+	// connection can tell it has been superseded.
+	connMu   sync.Mutex
+	connGen  uint64
+	connDone chan struct{}
+
+	// subscribedNames is the union of every event name ever passed to
+	// Subscribe, so Run can replay daemon.set_event_interest after a
+	// reconnect.
+	subscribeMu     sync.Mutex
+	subscribedNames map[string]struct{}
+}
+
+// pendingCall is what Rpc/RpcContext registers in Client.pending while
+// waiting for a response; method and startedAt exist only so the read loop
+// can attribute the response to a SessionStats entry once it arrives.
+type pendingCall struct {
+	ch        chan *DelugeResponse
+	method    string
+	startedAt time.Time
 }
 
 // RPCError is an error returned by RPC calls.
@@ -106,59 +158,33 @@ func (dr *DelugeResponse) String() string {
 	return fmt.Sprintf("invalid message type: %d", dr.messageType)
 }
 
-func (c *Client) resetTimeout() error {
+func (c *Client) resetTimeout(conn *tls.Conn) error {
 	// set timeout
-	return c.conn.SetDeadline(time.Now().Add(c.settings.ReadWriteTimeout))
+	return conn.SetWriteDeadline(time.Now().Add(c.settings.ReadWriteTimeout))
 }
 
-func (c *Client) Rpc(methodName string, args rencode.List, kwargs rencode.Dictionary) (*DelugeResponse, error) {
-	// generate serial
-	c.serial++
-	if c.serial == math.MaxInt64 {
-		c.serial = 1
-	}
-
-	// rencode -> zlib -> openssl -> TCP
-	b := bytes.Buffer{}
-	z := zlib.NewWriter(&b)
-	e := rencode.NewEncoder(z)
-
-	// payload is wrapped twice in a list because there is support for multiple RPC calls
-	// although not used currently
-	payload := rencode.NewList(rencode.NewList(c.serial, methodName, args, kwargs))
-
-	err := e.Encode(payload)
-	if err != nil {
-		return nil, err
-	}
-
-	// flush zlib-compressed buffer
-	err = z.Close()
-	if err != nil {
-		return nil, err
-	}
-	if c.settings.Logger != nil {
-		c.settings.Logger.Println("flushed zlib buffer")
-	}
-
-	// write to connection without closing it
-	var n int
-	n, err = c.conn.Write(b.Bytes())
-	if err != nil {
-		return nil, err
-	}
-	if c.settings.Logger != nil {
-		//		c.settings.Logger.Println(hex.Dump(b.Bytes()))
-		c.settings.Logger.Printf("written %d bytes to RPC connection", n)
-	}
+// countingReader wraps a reader to tally the bytes actually pulled off it,
+// so readFrame can attribute wire-level traffic to SessionStats without
+// Deluge exposing a content-length to read up front.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
 
-	err = c.resetTimeout()
-	if err != nil {
-		return nil, err
-	}
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
 
+// readFrame reads and decodes a single zlib-compressed rencode frame from
+// the connection. It is used by the persistent read loop, and it
+// understands all three message types, including rpcEvent ones that have no
+// caller to route a response to.
+func (c *Client) readFrame(conn *tls.Conn) (*DelugeResponse, error) {
 	// setup a reader: TCP -> openssl -> zlib -> rencode -> {objects}
-	zr, err := zlib.NewReader(c.conn)
+	cr := &countingReader{r: conn}
+	zr, err := zlib.NewReader(cr)
 	if err != nil {
 		return nil, err
 	}
@@ -176,9 +202,6 @@ func (c *Client) Rpc(methodName string, args rencode.List, kwargs rencode.Dictio
 	if err != nil {
 		return nil, err
 	}
-	if resp.requestID != c.serial {
-		return nil, errors.New("request/response serial id mismatch")
-	}
 	resp.messageType = rpcResponseTypeID(mt)
 
 	// shift first two elements
@@ -198,16 +221,220 @@ func (c *Client) Rpc(methodName string, args rencode.List, kwargs rencode.Dictio
 			return nil, err
 		}
 	case rpcEvent:
-		return nil, errors.New("event support not available")
+		err = respList.Scan(&resp.eventName, &resp.data)
+		if err != nil {
+			return nil, err
+		}
 	default:
 		return nil, errors.New("unknown message type")
 	}
 
+	c.stats.addBytesRecv(int(cr.n))
+
+	return &resp, nil
+}
+
+// readLoop runs for the lifetime of the connection, decoding frames as they
+// arrive and routing them: an rpcResponse/rpcError is delivered to the
+// caller waiting on its serial (if any still is), while an rpcEvent is
+// published on the event bus for any interested subscription. It replaces
+// the previous one-shot-per-call read, which left no way to observe events
+// pushed by the daemon outside of a pending Rpc call.
+//
+// done is closed when the loop returns, which is the signal RpcContext
+// waits on (alongside its own ctx) to learn the connection backing its
+// call has dropped. conn is the connection dialed for this attempt, read
+// directly rather than via c.conn so a concurrent reconnect swapping that
+// field can't be torn across frames read by this, now superseded, loop.
+func (c *Client) readLoop(conn *tls.Conn, done chan struct{}) {
+	defer func() {
+		close(done)
+		c.pendingMu.Lock()
+		c.pending = make(map[int64]*pendingCall)
+		c.pendingMu.Unlock()
+	}()
+
+	for {
+		resp, err := c.readFrame(conn)
+		if err != nil {
+			if c.settings.Logger != nil {
+				c.settings.Logger.Printf("read loop terminating: %v\n", err)
+			}
+			return
+		}
+
+		if resp.messageType == rpcEvent {
+			c.stats.recordEvent()
+			c.events.publish(decodeEvent(resp.eventName, resp.data))
+			continue
+		}
+
+		c.pendingMu.Lock()
+		call, ok := c.pending[resp.requestID]
+		if ok {
+			delete(c.pending, resp.requestID)
+		}
+		c.pendingMu.Unlock()
+
+		if !ok {
+			if c.settings.Logger != nil {
+				c.settings.Logger.Printf("no caller waiting for serial %d\n", resp.requestID)
+			}
+			continue
+		}
+		c.stats.recordCall(call.method, resp.IsError(), time.Since(call.startedAt))
+		call.ch <- resp
+	}
+}
+
+// writeFrame encodes and writes a single RPC request frame under writeMu,
+// which is the only thing serialized about a call: several Rpc calls can
+// have their responses pending concurrently, but the bytes that make up a
+// request must reach the wire as one atomic write.
+//
+// conn and gen are the connection and connection generation captured by the
+// caller at dispatch time, not read live off c.conn: RpcContext may be
+// preempted before this goroutine runs, and by then a reconnect could have
+// replaced c.conn with a new connection/session. Writing against the live
+// field would silently deliver this (already-abandoned, per the caller's
+// ErrDisconnected) request to the new session instead of erroring it.
+func (c *Client) writeFrame(conn *tls.Conn, gen uint64, serial int64, methodName string, args rencode.List, kwargs rencode.Dictionary) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	if _, _, curGen := c.currentConn(); curGen != gen {
+		return ErrDisconnected
+	}
+
+	// rencode -> zlib -> openssl -> TCP
+	b := bytes.Buffer{}
+	z := zlib.NewWriter(&b)
+	e := rencode.NewEncoder(z)
+
+	// payload is wrapped twice in a list because there is support for multiple RPC calls
+	// although not used currently
+	payload := rencode.NewList(rencode.NewList(serial, methodName, args, kwargs))
+
+	err := e.Encode(payload)
+	if err != nil {
+		return err
+	}
+
+	// flush zlib-compressed buffer
+	err = z.Close()
+	if err != nil {
+		return err
+	}
 	if c.settings.Logger != nil {
-		c.settings.Logger.Printf("RPC(%s) = %s\n", methodName, resp.String())
+		c.settings.Logger.Println("flushed zlib buffer")
 	}
 
-	return &resp, nil
+	err = c.resetTimeout(conn)
+	if err != nil {
+		return err
+	}
+
+	// write to connection without closing it
+	n, err := conn.Write(b.Bytes())
+	if err != nil {
+		return err
+	}
+	if c.settings.Logger != nil {
+		//		c.settings.Logger.Println(hex.Dump(b.Bytes()))
+		c.settings.Logger.Printf("written %d bytes to RPC connection", n)
+	}
+	c.stats.addBytesSent(n)
+
+	return nil
+}
+
+// nextSerial returns the next request serial, wrapping before it would
+// overflow. It is guarded by writeMu so that it can be called right before
+// writeFrame without a caller ever observing a duplicate serial.
+func (c *Client) nextSerial() int64 {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	c.serial++
+	if c.serial == math.MaxInt64 {
+		c.serial = 1
+	}
+	return c.serial
+}
+
+// Rpc performs a synchronous call, but several goroutines may call it at
+// once: each gets its own serial and its own channel in the pending map, so
+// calls are pipelined over the one underlying connection rather than
+// blocking each other for the full round-trip.
+func (c *Client) Rpc(methodName string, args rencode.List, kwargs rencode.Dictionary) (*DelugeResponse, error) {
+	ctx, cancel := c.WithTimeout()
+	defer cancel()
+	return c.RpcContext(ctx, methodName, args, kwargs)
+}
+
+// RpcContext is Rpc with cancellation: if ctx is done before the response
+// for this call arrives, the caller's serial is dropped from the pending
+// map and ctx.Err() is returned. Deluge has no way to cancel a single
+// in-flight call on the server side, so if ctx is done while the request is
+// still being written, the whole connection is closed to unblock it —
+// which also aborts any other calls sharing the connection at the time.
+func (c *Client) RpcContext(ctx context.Context, methodName string, args rencode.List, kwargs rencode.Dictionary) (*DelugeResponse, error) {
+	serial := c.nextSerial()
+	conn, connDone, gen := c.currentConn()
+	if conn == nil {
+		return nil, ErrDisconnected
+	}
+
+	ch := make(chan *DelugeResponse, 1)
+	c.pendingMu.Lock()
+	c.pending[serial] = &pendingCall{ch: ch, method: methodName, startedAt: time.Now()}
+	c.pendingMu.Unlock()
+
+	writeDone := make(chan error, 1)
+	go func() { writeDone <- c.writeFrame(conn, gen, serial, methodName, args, kwargs) }()
+
+	select {
+	case err := <-writeDone:
+		if err != nil {
+			c.pendingMu.Lock()
+			delete(c.pending, serial)
+			c.pendingMu.Unlock()
+			return nil, err
+		}
+	case <-ctx.Done():
+		c.pendingMu.Lock()
+		delete(c.pending, serial)
+		c.pendingMu.Unlock()
+		conn.Close()
+		return nil, ctx.Err()
+	case <-connDone:
+		return nil, ErrDisconnected
+	}
+
+	select {
+	case resp := <-ch:
+		if resp.requestID != serial {
+			return nil, errors.New("request/response serial id mismatch")
+		}
+		if c.settings.Logger != nil {
+			c.settings.Logger.Printf("RPC(%s) = %s\n", methodName, resp.String())
+		}
+		return resp, nil
+	case <-ctx.Done():
+		c.pendingMu.Lock()
+		delete(c.pending, serial)
+		c.pendingMu.Unlock()
+		return nil, ctx.Err()
+	case <-connDone:
+		return nil, ErrDisconnected
+	}
+}
+
+// WithTimeout returns a context bound to the client's configured
+// ReadWriteTimeout, for callers that want the previous per-call timeout
+// behavior without having to build their own context.Context.
+func (c *Client) WithTimeout() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), c.settings.ReadWriteTimeout)
 }
 
 // New returns a Deluge client.
@@ -216,47 +443,99 @@ func New(s Settings) *Client {
 		s.ReadWriteTimeout = DefaultReadWriteTimeout
 	}
 	return &Client{
-		settings: s,
+		settings:        s,
+		pending:         make(map[int64]*pendingCall),
+		events:          newEventBus(),
+		stats:           newClientStats(),
+		state:           int32(Disconnected),
+		readyCh:         make(chan struct{}),
+		connDone:        closedChan,
+		subscribedNames: make(map[string]struct{}),
 	}
 }
 
 // Close closes the connection of a Deluge client.
 func (c *Client) Close() error {
-	if c.conn == nil {
+	c.connMu.Lock()
+	conn := c.conn
+	c.conn = nil
+	c.connMu.Unlock()
+
+	if conn == nil {
 		return ErrAlreadyClosed
 	}
-	err := c.conn.Close()
-	c.conn = nil
+	err := conn.Close()
+	c.setState(Disconnected)
 	return err
 }
 
 // Connect performs connection to a Deluge daemon second previously specified settings.
 func (c *Client) Connect() error {
-	var err error
-	c.conn, err = tls.Dial("tcp", fmt.Sprintf("%s:%d", c.settings.Hostname, c.settings.Port),
-		&tls.Config{
+	ctx, cancel := c.WithTimeout()
+	defer cancel()
+	return c.ConnectContext(ctx)
+}
+
+// ConnectContext is Connect with cancellation: a ctx that is done before
+// the TLS handshake and login complete aborts the dial (or, if the
+// handshake already succeeded, the login call) instead of hanging for the
+// whole ReadWriteTimeout.
+func (c *Client) ConnectContext(ctx context.Context) error {
+	c.setState(Connecting)
+
+	dialer := tls.Dialer{
+		Config: &tls.Config{
 			InsecureSkipVerify: true, // x509: cannot verify signature: algorithm unimplemented
-		})
+		},
+	}
+	conn, err := dialer.DialContext(ctx, "tcp", fmt.Sprintf("%s:%d", c.settings.Hostname, c.settings.Port))
 	if err != nil {
+		c.setState(Disconnected)
 		return err
 	}
-
 	if c.settings.Logger != nil {
 		c.settings.Logger.Printf("connected to %s:%d\n", c.settings.Hostname, c.settings.Port)
 	}
 
+	// the read loop owns decoding frames off the wire for the lifetime of
+	// the connection, so that events can be delivered even while no RPC
+	// call is in flight; connDone is its disconnect signal. conn and
+	// connGen are updated alongside it, under the same lock, so a call
+	// dispatched concurrently with this reconnect sees a consistent
+	// (conn, connDone, connGen) triple via currentConn.
+	newConn := conn.(*tls.Conn)
+	done := make(chan struct{})
+	c.connMu.Lock()
+	prev := c.conn
+	c.conn = newConn
+	c.connDone = done
+	c.connGen++
+	c.connMu.Unlock()
+	if prev != nil {
+		// prev is whatever ConnectContext last installed: either the
+		// socket readLoop just gave up on, or one left dangling by a
+		// previous attempt that dialed fine but failed to log in. Either
+		// way nothing else still references it, so it would otherwise
+		// leak a file descriptor on every reconnect.
+		prev.Close()
+	}
+	go c.readLoop(newConn, done)
+
 	// perform login
-	resp, err := c.Rpc("daemon.login", rencode.NewList(c.settings.Login, c.settings.Password), rencode.Dictionary{})
+	resp, err := c.RpcContext(ctx, "daemon.login", rencode.NewList(c.settings.Login, c.settings.Password), rencode.Dictionary{})
 	if err != nil {
+		c.setState(Disconnected)
 		return err
 	}
 	if resp.IsError() {
+		c.setState(Disconnected)
 		return RPCError{resp.String()}
 	}
 
 	// get class of logged-in user
 	err = resp.returnValue.Scan(&c.classID)
 	if err != nil {
+		c.setState(Disconnected)
 		return err
 	}
 
@@ -264,12 +543,20 @@ func (c *Client) Connect() error {
 		c.settings.Logger.Println("login successful as user", c.settings.Login)
 	}
 
+	c.setState(Ready)
+
 	return nil
 }
 
 // MethodsList returns a list of available methods on server.
 func (c *Client) MethodsList() ([]string, error) {
-	resp, err := c.Rpc("daemon.get_method_list", rencode.List{}, rencode.Dictionary{})
+	ctx, cancel := c.WithTimeout()
+	defer cancel()
+	return c.MethodsListContext(ctx)
+}
+
+func (c *Client) MethodsListContext(ctx context.Context) ([]string, error) {
+	resp, err := c.RpcContext(ctx, "daemon.get_method_list", rencode.List{}, rencode.Dictionary{})
 	if err != nil {
 		return []string{}, err
 	}
@@ -292,7 +579,13 @@ func (c *Client) MethodsList() ([]string, error) {
 
 // DaemonVersion returns the running daemon version.
 func (c *Client) DaemonVersion() (string, error) {
-	resp, err := c.Rpc("daemon.info", rencode.List{}, rencode.Dictionary{})
+	ctx, cancel := c.WithTimeout()
+	defer cancel()
+	return c.DaemonVersionContext(ctx)
+}
+
+func (c *Client) DaemonVersionContext(ctx context.Context) (string, error) {
+	resp, err := c.RpcContext(ctx, "daemon.info", rencode.List{}, rencode.Dictionary{})
 	if err != nil {
 		return "", err
 	}
@@ -331,10 +624,16 @@ func sliceToRencodeList(s []string) rencode.List {
 
 // AddTorrentMagnet adds a torrent via magnet URI and returns the torrent hash.
 func (c *Client) AddTorrentMagnet(magnetURI string, options Options) (string, error) {
+	ctx, cancel := c.WithTimeout()
+	defer cancel()
+	return c.AddTorrentMagnetContext(ctx, magnetURI, options)
+}
+
+func (c *Client) AddTorrentMagnetContext(ctx context.Context, magnetURI string, options Options) (string, error) {
 	var args rencode.List
 	args.Add(magnetURI, mapToRencodeDictionary(options))
 
-	resp, err := c.Rpc("core.add_torrent_magnet", args, rencode.Dictionary{})
+	resp, err := c.RpcContext(ctx, "core.add_torrent_magnet", args, rencode.Dictionary{})
 	if err != nil {
 		return "", err
 	}
@@ -355,10 +654,48 @@ func (c *Client) AddTorrentMagnet(magnetURI string, options Options) (string, er
 
 // AddTorrentURL adds a torrent via a URL and returns the torrent hash.
 func (c *Client) AddTorrentURL(url string, options Options) (string, error) {
+	ctx, cancel := c.WithTimeout()
+	defer cancel()
+	return c.AddTorrentURLContext(ctx, url, options)
+}
+
+func (c *Client) AddTorrentURLContext(ctx context.Context, url string, options Options) (string, error) {
 	var args rencode.List
 	args.Add(url, mapToRencodeDictionary(options))
 
-	resp, err := c.Rpc("core.add_torrent_url", args, rencode.Dictionary{})
+	resp, err := c.RpcContext(ctx, "core.add_torrent_url", args, rencode.Dictionary{})
+	if err != nil {
+		return "", err
+	}
+	if resp.IsError() {
+		return "", RPCError{resp.String()}
+	}
+
+	// returned hash may be nil if torrent was already added
+	torrentHash, err := resp.returnValue.Get(0)
+	if err != nil {
+		return "", err
+	}
+	if torrentHash == nil {
+		return "", nil
+	}
+	return string(torrentHash.([]uint8)), nil
+}
+
+// AddTorrentFile adds a torrent from local .torrent metainfo bytes and
+// returns the torrent hash. filename is only used by the daemon for
+// logging/display purposes; it does not need to exist on the daemon side.
+func (c *Client) AddTorrentFile(filename string, metainfoBytes []byte, options Options) (string, error) {
+	ctx, cancel := c.WithTimeout()
+	defer cancel()
+	return c.AddTorrentFileContext(ctx, filename, metainfoBytes, options)
+}
+
+func (c *Client) AddTorrentFileContext(ctx context.Context, filename string, metainfoBytes []byte, options Options) (string, error) {
+	var args rencode.List
+	args.Add(filename, base64.StdEncoding.EncodeToString(metainfoBytes), mapToRencodeDictionary(options))
+
+	resp, err := c.RpcContext(ctx, "core.add_torrent_file", args, rencode.Dictionary{})
 	if err != nil {
 		return "", err
 	}
@@ -377,11 +714,34 @@ func (c *Client) AddTorrentURL(url string, options Options) (string, error) {
 	return string(torrentHash.([]uint8)), nil
 }
 
+// AddTorrentFilePath reads a .torrent file from disk and adds it, as a
+// convenience over AddTorrentFile for callers that already have it on disk
+// rather than in memory.
+func (c *Client) AddTorrentFilePath(path string, options Options) (string, error) {
+	ctx, cancel := c.WithTimeout()
+	defer cancel()
+	return c.AddTorrentFilePathContext(ctx, path, options)
+}
+
+func (c *Client) AddTorrentFilePathContext(ctx context.Context, path string, options Options) (string, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return c.AddTorrentFileContext(ctx, filepath.Base(path), b, options)
+}
+
 func (c *Client) MoveStorage(torrentIDs []string, dest string) error {
+	ctx, cancel := c.WithTimeout()
+	defer cancel()
+	return c.MoveStorageContext(ctx, torrentIDs, dest)
+}
+
+func (c *Client) MoveStorageContext(ctx context.Context, torrentIDs []string, dest string) error {
 	var args rencode.List
 	args.Add(sliceToRencodeList(torrentIDs), dest)
 
-	resp, err := c.Rpc("core.move_storage", args, rencode.Dictionary{})
+	resp, err := c.RpcContext(ctx, "core.move_storage", args, rencode.Dictionary{})
 	if err != nil {
 		return err
 	}
@@ -393,10 +753,16 @@ func (c *Client) MoveStorage(torrentIDs []string, dest string) error {
 }
 
 func (c *Client) RemoveTorrent(torrentID string, removeData bool) (bool, error) {
+	ctx, cancel := c.WithTimeout()
+	defer cancel()
+	return c.RemoveTorrentContext(ctx, torrentID, removeData)
+}
+
+func (c *Client) RemoveTorrentContext(ctx context.Context, torrentID string, removeData bool) (bool, error) {
 	var args rencode.List
 	args.Add(torrentID, removeData)
 
-	resp, err := c.Rpc("core.remove_torrent", args, rencode.Dictionary{})
+	resp, err := c.RpcContext(ctx, "core.remove_torrent", args, rencode.Dictionary{})
 	if err != nil {
 		return false, err
 	}
@@ -408,9 +774,15 @@ func (c *Client) RemoveTorrent(torrentID string, removeData bool) (bool, error)
 }
 
 func (c *Client) GetTorrentStatus(torrentID string, keys []string, diff bool) (map[string]interface{}, error) {
+	ctx, cancel := c.WithTimeout()
+	defer cancel()
+	return c.GetTorrentStatusContext(ctx, torrentID, keys, diff)
+}
+
+func (c *Client) GetTorrentStatusContext(ctx context.Context, torrentID string, keys []string, diff bool) (map[string]interface{}, error) {
 	var args rencode.List
 	args.Add(torrentID, sliceToRencodeList(keys), diff)
-	resp, err := c.Rpc("core.get_torrent_status", args, rencode.Dictionary{})
+	resp, err := c.RpcContext(ctx, "core.get_torrent_status", args, rencode.Dictionary{})
 	if err != nil {
 		return nil, err
 	}
@@ -439,6 +811,12 @@ func (c *Client) GetTorrentStatus(torrentID string, keys []string, diff bool) (m
 }
 
 func (c *Client) GetTorrentsStatus(filter map[string][]string, keys []string, diff bool) (map[string]interface{}, error) {
+	ctx, cancel := c.WithTimeout()
+	defer cancel()
+	return c.GetTorrentsStatusContext(ctx, filter, keys, diff)
+}
+
+func (c *Client) GetTorrentsStatusContext(ctx context.Context, filter map[string][]string, keys []string, diff bool) (map[string]interface{}, error) {
 	log.Printf("GetTorrentsStatus filter: %#v \n", filter)
 	var filterDict rencode.Dictionary
 	for k, v := range filter {
@@ -448,7 +826,7 @@ func (c *Client) GetTorrentsStatus(filter map[string][]string, keys []string, di
 	var args rencode.List
 	args.Add(filterDict, sliceToRencodeList(keys), diff)
 	log.Printf("GetTorrentsStatus args: %#v \n", args)
-	resp, err := c.Rpc("core.get_torrents_status", args, rencode.Dictionary{})
+	resp, err := c.RpcContext(ctx, "core.get_torrents_status", args, rencode.Dictionary{})
 	if err != nil {
 		return nil, err
 	}
@@ -487,7 +865,13 @@ func (c *Client) GetTorrentsStatus(filter map[string][]string, keys []string, di
 }
 
 func (c *Client) GetSessionState() ([]string, error) {
-	resp, err := c.Rpc("core.get_session_state", rencode.List{}, rencode.Dictionary{})
+	ctx, cancel := c.WithTimeout()
+	defer cancel()
+	return c.GetSessionStateContext(ctx)
+}
+
+func (c *Client) GetSessionStateContext(ctx context.Context) ([]string, error) {
+	resp, err := c.RpcContext(ctx, "core.get_session_state", rencode.List{}, rencode.Dictionary{})
 	if err != nil {
 		return nil, err
 	}
@@ -507,3 +891,43 @@ func (c *Client) GetSessionState() ([]string, error) {
 
 	return result, nil
 }
+
+// Subscribe registers interest in the given Deluge event names with the
+// daemon and returns a Subscription whose Events() channel yields them as
+// they are pushed. Passing no names subscribes to every event name this
+// package knows about, since daemon.set_event_interest has no wildcard of
+// its own.
+func (c *Client) Subscribe(eventNames ...string) (*Subscription, error) {
+	ctx, cancel := c.WithTimeout()
+	defer cancel()
+	return c.SubscribeContext(ctx, eventNames...)
+}
+
+func (c *Client) SubscribeContext(ctx context.Context, eventNames ...string) (*Subscription, error) {
+	sendNames := eventNames
+	if len(sendNames) == 0 {
+		sendNames = allEventNames
+	}
+
+	var args rencode.List
+	args.Add(sliceToRencodeList(sendNames))
+
+	resp, err := c.RpcContext(ctx, "daemon.set_event_interest", args, rencode.Dictionary{})
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsError() {
+		return nil, RPCError{resp.String()}
+	}
+
+	sub := newSubscription(c.events, eventNames)
+	c.events.subscribe(sub)
+
+	c.subscribeMu.Lock()
+	for _, name := range sendNames {
+		c.subscribedNames[name] = struct{}{}
+	}
+	c.subscribeMu.Unlock()
+
+	return sub, nil
+}
@@ -0,0 +1,225 @@
+/*
+ * go-libdeluge v0.1.0 - a native deluge RPC client library
+ * Copyright (C) 2015~2016 gdm85 - https://github.com/gdm85/go-libdeluge/
+This program is free software; you can redistribute it and/or
+modify it under the terms of the GNU General Public License
+as published by the Free Software Foundation; either version 2
+of the License, or (at your option) any later version.
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+You should have received a copy of the GNU General Public License
+along with this program; if not, write to the Free Software
+Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+*/
+package delugeclient
+
+import (
+	"sync"
+
+	"github.com/gdm85/go-rencode"
+)
+
+// eventSubscriptionBuffer is the per-Subscription channel capacity; a
+// subscriber slower than the event rate drops events rather than blocking
+// the read loop.
+const eventSubscriptionBuffer = 32
+
+// well-known event names pushed by the Deluge daemon.
+const (
+	EventTorrentAdded        = "TorrentAddedEvent"
+	EventTorrentRemoved      = "TorrentRemovedEvent"
+	EventTorrentStateChanged = "TorrentStateChangedEvent"
+	EventTorrentFinished     = "TorrentFinishedEvent"
+)
+
+// allEventNames is every event name above, used by SubscribeContext to ask
+// the daemon for "everything" when the caller passes no names: daemon.set_event_interest
+// has no wildcard of its own, so "everything" can only mean every name this
+// package knows about.
+var allEventNames = []string{
+	EventTorrentAdded,
+	EventTorrentRemoved,
+	EventTorrentStateChanged,
+	EventTorrentFinished,
+}
+
+// Event is implemented by every value delivered on a Subscription's
+// channel, including TorrentAddedEvent and friends, as well as
+// UnknownEvent for event names without a dedicated Go type.
+type Event interface {
+	Name() string
+}
+
+// TorrentAddedEvent is fired when a torrent is added to the session.
+type TorrentAddedEvent struct {
+	TorrentID string
+	FromState bool
+}
+
+// Name returns the Deluge event name.
+func (TorrentAddedEvent) Name() string { return EventTorrentAdded }
+
+// TorrentRemovedEvent is fired when a torrent is removed from the session.
+type TorrentRemovedEvent struct {
+	TorrentID string
+}
+
+// Name returns the Deluge event name.
+func (TorrentRemovedEvent) Name() string { return EventTorrentRemoved }
+
+// TorrentStateChangedEvent is fired when a torrent transitions state, e.g.
+// from "Downloading" to "Seeding".
+type TorrentStateChangedEvent struct {
+	TorrentID string
+	State     string
+}
+
+// Name returns the Deluge event name.
+func (TorrentStateChangedEvent) Name() string { return EventTorrentStateChanged }
+
+// TorrentFinishedEvent is fired when a torrent completes downloading.
+type TorrentFinishedEvent struct {
+	TorrentID string
+}
+
+// Name returns the Deluge event name.
+func (TorrentFinishedEvent) Name() string { return EventTorrentFinished }
+
+// UnknownEvent is delivered for event names without a dedicated Go type
+// above; Data holds the raw rencode payload the daemon sent.
+type UnknownEvent struct {
+	EventName string
+	Data      rencode.List
+}
+
+// Name returns the Deluge event name.
+func (e UnknownEvent) Name() string { return e.EventName }
+
+// decodeEvent turns a raw eventName/data pair received from the daemon into
+// a typed Event, falling back to UnknownEvent for anything not listed
+// above.
+func decodeEvent(name string, data rencode.List) Event {
+	values := data.Values()
+
+	str := func(i int) string {
+		if i >= len(values) {
+			return ""
+		}
+		b, ok := values[i].([]byte)
+		if !ok {
+			return ""
+		}
+		return string(b)
+	}
+
+	switch name {
+	case EventTorrentAdded:
+		fromState := false
+		if len(values) > 1 {
+			fromState, _ = values[1].(bool)
+		}
+		return TorrentAddedEvent{TorrentID: str(0), FromState: fromState}
+	case EventTorrentRemoved:
+		return TorrentRemovedEvent{TorrentID: str(0)}
+	case EventTorrentStateChanged:
+		return TorrentStateChangedEvent{TorrentID: str(0), State: str(1)}
+	case EventTorrentFinished:
+		return TorrentFinishedEvent{TorrentID: str(0)}
+	default:
+		return UnknownEvent{EventName: name, Data: data}
+	}
+}
+
+// Subscription delivers events requested via Client.Subscribe. It must be
+// closed with Close once no longer needed, to unregister from the bus and
+// release its channel.
+type Subscription struct {
+	bus    *eventBus
+	names  map[string]bool
+	events chan Event
+
+	closeOnce sync.Once
+}
+
+func newSubscription(bus *eventBus, eventNames []string) *Subscription {
+	names := make(map[string]bool, len(eventNames))
+	for _, n := range eventNames {
+		names[n] = true
+	}
+	return &Subscription{
+		bus:    bus,
+		names:  names,
+		events: make(chan Event, eventSubscriptionBuffer),
+	}
+}
+
+// wants reports whether this subscription is interested in the named
+// event; an empty name set means "everything".
+func (s *Subscription) wants(name string) bool {
+	if len(s.names) == 0 {
+		return true
+	}
+	return s.names[name]
+}
+
+// Events returns the channel on which subscribed events are delivered.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Close unregisters the subscription from the bus and closes its events
+// channel, so a caller ranging over Events() with "for ev := range
+// sub.Events()" terminates instead of blocking forever: unsubscribe removes
+// s from the bus under the bus's lock first, and publish takes that same
+// lock, so no send on events can be in flight once it is closed here. Close
+// is safe to call more than once.
+func (s *Subscription) Close() error {
+	s.closeOnce.Do(func() {
+		s.bus.unsubscribe(s)
+		close(s.events)
+	})
+	return nil
+}
+
+// eventBus fans out events decoded by the read loop to every interested
+// Subscription, mirroring the pubsub pattern anacrolix/torrent's Client
+// uses for its own internal event delivery.
+type eventBus struct {
+	mu   sync.Mutex
+	subs map[*Subscription]struct{}
+}
+
+func newEventBus() *eventBus {
+	return &eventBus{subs: make(map[*Subscription]struct{})}
+}
+
+func (b *eventBus) subscribe(s *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subs[s] = struct{}{}
+}
+
+func (b *eventBus) unsubscribe(s *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.subs, s)
+}
+
+// publish delivers ev to every subscription interested in it. A
+// subscription whose channel is full drops the event rather than blocking
+// the read loop that every other caller and subscriber depends on.
+func (b *eventBus) publish(ev Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for s := range b.subs {
+		if !s.wants(ev.Name()) {
+			continue
+		}
+		select {
+		case s.events <- ev:
+		default:
+		}
+	}
+}
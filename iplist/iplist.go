@@ -0,0 +1,135 @@
+/*
+ * go-libdeluge v0.1.0 - a native deluge RPC client library
+ * Copyright (C) 2015~2016 gdm85 - https://github.com/gdm85/go-libdeluge/
+This program is free software; you can redistribute it and/or
+modify it under the terms of the GNU General Public License
+as published by the Free Software Foundation; either version 2
+of the License, or (at your option) any later version.
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+You should have received a copy of the GNU General Public License
+along with this program; if not, write to the Free Software
+Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+*/
+
+// Package iplist parses P2P-format IP range blocklists, the same format
+// Deluge's Blocklist plugin downloads and imports, so callers can
+// pre-validate a list before pointing the daemon at its URL. It mirrors the
+// shape of anacrolix/torrent's iplist package (a Range type plus a
+// Ranger-like lookup), reduced to what a client library needs.
+package iplist
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strings"
+)
+
+// Range is one blocked IP range, as found in a P2P-format list line:
+// "<description>:<first ip>-<last ip>".
+type Range struct {
+	Description string
+	First       net.IP
+	Last        net.IP
+}
+
+func (r Range) String() string {
+	return fmt.Sprintf("%s:%s-%s", r.Description, r.First, r.Last)
+}
+
+// IPList is a parsed, sorted set of Ranges that can be queried with
+// Lookup.
+type IPList struct {
+	ranges []Range
+}
+
+// Parse reads a P2P-format blocklist (one "description:first-last" range
+// per line; blank lines and lines starting with '#' are ignored) and
+// returns it as a queryable IPList.
+func Parse(r io.Reader) (*IPList, error) {
+	var ranges []Range
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rng, err := parseLine(line)
+		if err != nil {
+			return nil, err
+		}
+		ranges = append(ranges, rng)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(ranges, func(i, j int) bool {
+		return bytesCompare(ranges[i].First, ranges[j].First) < 0
+	})
+
+	return &IPList{ranges: ranges}, nil
+}
+
+func parseLine(line string) (Range, error) {
+	// description can itself contain ':', so split off the last field.
+	sep := strings.LastIndex(line, ":")
+	if sep < 0 {
+		return Range{}, fmt.Errorf("iplist: malformed line (missing description separator): %q", line)
+	}
+	description := line[:sep]
+	span := line[sep+1:]
+
+	dash := strings.Index(span, "-")
+	if dash < 0 {
+		return Range{}, fmt.Errorf("iplist: malformed line (missing range separator): %q", line)
+	}
+
+	first := net.ParseIP(strings.TrimSpace(span[:dash]))
+	last := net.ParseIP(strings.TrimSpace(span[dash+1:]))
+	if first == nil || last == nil {
+		return Range{}, fmt.Errorf("iplist: malformed line (invalid IP): %q", line)
+	}
+
+	return Range{Description: description, First: first, Last: last}, nil
+}
+
+// Len returns the number of ranges in the list.
+func (l *IPList) Len() int {
+	return len(l.ranges)
+}
+
+// Ranges returns every range in the list, sorted by starting address.
+func (l *IPList) Ranges() []Range {
+	return l.ranges
+}
+
+// Lookup returns the range containing ip, if any.
+func (l *IPList) Lookup(ip net.IP) (Range, bool) {
+	for _, r := range l.ranges {
+		if bytesCompare(ip, r.First) >= 0 && bytesCompare(ip, r.Last) <= 0 {
+			return r, true
+		}
+	}
+	return Range{}, false
+}
+
+// bytesCompare compares two IPs by normalizing both to 16-byte form first,
+// so a v4 and a v4-in-v6 address compare equal when they represent the
+// same address.
+func bytesCompare(a, b net.IP) int {
+	a16, b16 := a.To16(), b.To16()
+	for i := 0; i < len(a16) && i < len(b16); i++ {
+		if a16[i] != b16[i] {
+			return int(a16[i]) - int(b16[i])
+		}
+	}
+	return 0
+}
@@ -0,0 +1,139 @@
+/*
+ * go-libdeluge v0.1.0 - a native deluge RPC client library
+ * Copyright (C) 2015~2016 gdm85 - https://github.com/gdm85/go-libdeluge/
+This program is free software; you can redistribute it and/or
+modify it under the terms of the GNU General Public License
+as published by the Free Software Foundation; either version 2
+of the License, or (at your option) any later version.
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+You should have received a copy of the GNU General Public License
+along with this program; if not, write to the Free Software
+Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+*/
+package iplist
+
+import (
+	"net"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	const list = `# comment, should be skipped
+
+Some Org:1.2.3.0-1.2.3.255
+Another:One:10.0.0.0-10.0.0.10
+`
+	l, err := Parse(strings.NewReader(list))
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if l.Len() != 2 {
+		t.Fatalf("Len() = %d, want 2", l.Len())
+	}
+
+	// ranges are sorted by starting address
+	ranges := l.Ranges()
+	for i := 1; i < len(ranges); i++ {
+		if bytesCompare(ranges[i-1].First, ranges[i].First) > 0 {
+			t.Errorf("ranges not sorted: %v before %v", ranges[i-1], ranges[i])
+		}
+	}
+
+	// a description containing ':' is preserved as a single field, split
+	// off from the range by the *last* ':' on the line
+	found := false
+	for _, r := range ranges {
+		if r.Description == "Another:One" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("ranges = %v, want a range with description %q", ranges, "Another:One")
+	}
+}
+
+func TestParseMalformedLines(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+	}{
+		{"missing description separator", "1.2.3.0-1.2.3.255"},
+		{"missing range separator", "Some Org:1.2.3.0"},
+		{"invalid first ip", "Some Org:not-an-ip-1.2.3.255"},
+		{"invalid last ip", "Some Org:1.2.3.0-not-an-ip"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(strings.NewReader(tt.line)); err == nil {
+				t.Errorf("Parse(%q) succeeded, want error", tt.line)
+			}
+		})
+	}
+}
+
+func TestLookupIPv4(t *testing.T) {
+	const list = `Blocked:1.2.3.0-1.2.3.255`
+	l, err := Parse(strings.NewReader(list))
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"1.2.3.0", true},
+		{"1.2.3.128", true},
+		{"1.2.3.255", true},
+		{"1.2.2.255", false},
+		{"1.2.4.0", false},
+	}
+	for _, tt := range tests {
+		_, got := l.Lookup(net.ParseIP(tt.ip))
+		if got != tt.want {
+			t.Errorf("Lookup(%s) = %v, want %v", tt.ip, got, tt.want)
+		}
+	}
+}
+
+// TestLookupIPv6 builds an IPList directly rather than via Parse, since the
+// P2P line format's "description:first-last" split on the line's last ':'
+// only works when neither address contains one, i.e. IPv4; Range and Lookup
+// themselves are address-family agnostic.
+func TestLookupIPv6(t *testing.T) {
+	l := &IPList{ranges: []Range{
+		{Description: "Blocked", First: net.ParseIP("2001:db8::"), Last: net.ParseIP("2001:db8::ff")},
+	}}
+
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"2001:db8::", true},
+		{"2001:db8::80", true},
+		{"2001:db8::ff", true},
+		{"2001:db8::100", false},
+		{"2001:db9::", false},
+	}
+	for _, tt := range tests {
+		_, got := l.Lookup(net.ParseIP(tt.ip))
+		if got != tt.want {
+			t.Errorf("Lookup(%s) = %v, want %v", tt.ip, got, tt.want)
+		}
+	}
+}
+
+func TestLookupNotFound(t *testing.T) {
+	l, err := Parse(strings.NewReader("Blocked:1.2.3.0-1.2.3.255"))
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if _, ok := l.Lookup(net.ParseIP("8.8.8.8")); ok {
+		t.Error("Lookup(8.8.8.8) = true, want false")
+	}
+}
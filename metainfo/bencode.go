@@ -0,0 +1,139 @@
+/*
+ * go-libdeluge v0.1.0 - a native deluge RPC client library
+ * Copyright (C) 2015~2016 gdm85 - https://github.com/gdm85/go-libdeluge/
+This program is free software; you can redistribute it and/or
+modify it under the terms of the GNU General Public License
+as published by the Free Software Foundation; either version 2
+of the License, or (at your option) any later version.
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+You should have received a copy of the GNU General Public License
+along with this program; if not, write to the Free Software
+Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+*/
+package metainfo
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// decoder is a minimal recursive-descent bencode decoder. It only needs to
+// understand enough of the grammar to walk a .torrent file: byte strings
+// decode to Go strings, integers to int64, lists to []interface{} and
+// dictionaries to map[string]interface{}.
+type decoder struct {
+	data []byte
+	pos  int
+}
+
+func (d *decoder) decodeValue() (interface{}, error) {
+	if d.pos >= len(d.data) {
+		return nil, errors.New("metainfo: unexpected end of input")
+	}
+
+	switch d.data[d.pos] {
+	case 'i':
+		return d.decodeInt()
+	case 'l':
+		return d.decodeList()
+	case 'd':
+		v, _, err := d.decodeDict()
+		return v, err
+	default:
+		return d.decodeString()
+	}
+}
+
+func (d *decoder) decodeInt() (int64, error) {
+	// "i<digits>e"
+	d.pos++ // consume 'i'
+	start := d.pos
+	for d.pos < len(d.data) && d.data[d.pos] != 'e' {
+		d.pos++
+	}
+	if d.pos >= len(d.data) {
+		return 0, errors.New("metainfo: unterminated integer")
+	}
+	n, err := strconv.ParseInt(string(d.data[start:d.pos]), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("metainfo: invalid integer: %w", err)
+	}
+	d.pos++ // consume 'e'
+	return n, nil
+}
+
+func (d *decoder) decodeString() (string, error) {
+	// "<length>:<bytes>"
+	start := d.pos
+	for d.pos < len(d.data) && d.data[d.pos] != ':' {
+		d.pos++
+	}
+	if d.pos >= len(d.data) {
+		return "", errors.New("metainfo: unterminated string length")
+	}
+	length, err := strconv.Atoi(string(d.data[start:d.pos]))
+	if err != nil {
+		return "", fmt.Errorf("metainfo: invalid string length: %w", err)
+	}
+	d.pos++ // consume ':'
+	if length < 0 || d.pos+length > len(d.data) {
+		return "", errors.New("metainfo: string runs past end of input")
+	}
+	s := string(d.data[d.pos : d.pos+length])
+	d.pos += length
+	return s, nil
+}
+
+func (d *decoder) decodeList() ([]interface{}, error) {
+	d.pos++ // consume 'l'
+	var list []interface{}
+	for {
+		if d.pos >= len(d.data) {
+			return nil, errors.New("metainfo: unterminated list")
+		}
+		if d.data[d.pos] == 'e' {
+			d.pos++
+			return list, nil
+		}
+		v, err := d.decodeValue()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, v)
+	}
+}
+
+// decodeDict returns the decoded dictionary as well as the raw bencoded
+// bytes of each of its values, keyed by the same dictionary key. Callers
+// that need to hash a sub-dictionary exactly as it appeared on the wire
+// (e.g. the "info" dictionary, for an info hash) use the latter instead of
+// re-encoding the decoded value.
+func (d *decoder) decodeDict() (map[string]interface{}, map[string][]byte, error) {
+	d.pos++ // consume 'd'
+	dict := make(map[string]interface{})
+	raw := make(map[string][]byte)
+	for {
+		if d.pos >= len(d.data) {
+			return nil, nil, errors.New("metainfo: unterminated dictionary")
+		}
+		if d.data[d.pos] == 'e' {
+			d.pos++
+			return dict, raw, nil
+		}
+		key, err := d.decodeString()
+		if err != nil {
+			return nil, nil, err
+		}
+		start := d.pos
+		value, err := d.decodeValue()
+		if err != nil {
+			return nil, nil, err
+		}
+		dict[key] = value
+		raw[key] = d.data[start:d.pos]
+	}
+}
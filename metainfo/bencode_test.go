@@ -0,0 +1,219 @@
+/*
+ * go-libdeluge v0.1.0 - a native deluge RPC client library
+ * Copyright (C) 2015~2016 gdm85 - https://github.com/gdm85/go-libdeluge/
+This program is free software; you can redistribute it and/or
+modify it under the terms of the GNU General Public License
+as published by the Free Software Foundation; either version 2
+of the License, or (at your option) any later version.
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+You should have received a copy of the GNU General Public License
+along with this program; if not, write to the Free Software
+Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+*/
+package metainfo
+
+import (
+	"crypto/sha1"
+	"reflect"
+	"testing"
+)
+
+func TestDecodeValue(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want interface{}
+	}{
+		{"string", "4:spam", "spam"},
+		{"empty string", "0:", ""},
+		{"positive int", "i42e", int64(42)},
+		{"negative int", "i-3e", int64(-3)},
+		{"zero int", "i0e", int64(0)},
+		{"empty list", "le", []interface{}(nil)},
+		{"flat list", "l4:spam4:eggse", []interface{}{"spam", "eggs"}},
+		{"nested list", "l4:spaml1:a1:bee", []interface{}{"spam", []interface{}{"a", "b"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &decoder{data: []byte(tt.in)}
+			got, err := d.decodeValue()
+			if err != nil {
+				t.Fatalf("decodeValue(%q) returned error: %v", tt.in, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("decodeValue(%q) = %#v, want %#v", tt.in, got, tt.want)
+			}
+			if d.pos != len(tt.in) {
+				t.Errorf("decodeValue(%q) left pos at %d, want %d", tt.in, d.pos, len(tt.in))
+			}
+		})
+	}
+}
+
+func TestDecodeDict(t *testing.T) {
+	in := "d3:bar4:spam3:fooi42ee"
+	d := &decoder{data: []byte(in)}
+	dict, raw, err := d.decodeDict()
+	if err != nil {
+		t.Fatalf("decodeDict(%q) returned error: %v", in, err)
+	}
+	want := map[string]interface{}{"bar": "spam", "foo": int64(42)}
+	if !reflect.DeepEqual(dict, want) {
+		t.Errorf("decodeDict(%q) = %#v, want %#v", in, dict, want)
+	}
+	if string(raw["bar"]) != "4:spam" {
+		t.Errorf("raw[bar] = %q, want %q", raw["bar"], "4:spam")
+	}
+	if string(raw["foo"]) != "i42e" {
+		t.Errorf("raw[foo] = %q, want %q", raw["foo"], "i42e")
+	}
+}
+
+func TestDecodeDictNested(t *testing.T) {
+	// d3:subd1:ai1eee -> {"sub": {"a": 1}}
+	in := "d3:subd1:ai1eee"
+	d := &decoder{data: []byte(in)}
+	dict, raw, err := d.decodeDict()
+	if err != nil {
+		t.Fatalf("decodeDict(%q) returned error: %v", in, err)
+	}
+	want := map[string]interface{}{"sub": map[string]interface{}{"a": int64(1)}}
+	if !reflect.DeepEqual(dict, want) {
+		t.Errorf("decodeDict(%q) = %#v, want %#v", in, dict, want)
+	}
+	if string(raw["sub"]) != "d1:ai1ee" {
+		t.Errorf("raw[sub] = %q, want %q", raw["sub"], "d1:ai1ee")
+	}
+}
+
+func TestDecodeValueMalformed(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+	}{
+		{"empty input", ""},
+		{"unterminated integer", "i42"},
+		{"non-numeric integer", "iXe"},
+		{"unterminated string length", "4"},
+		{"string runs past end", "10:short"},
+		{"negative string length", "-1:x"},
+		{"unterminated list", "l4:spam"},
+		{"unterminated dict", "d3:foo"},
+		{"dict key not a string", "di1ei2ee"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &decoder{data: []byte(tt.in)}
+			if _, err := d.decodeValue(); err == nil {
+				t.Errorf("decodeValue(%q) succeeded, want error", tt.in)
+			}
+		})
+	}
+}
+
+// bencodeInfoDict returns a minimal bencoded info dictionary, either
+// single-file (files == nil) or multi-file.
+func bencodeInfoDict(name string, length int64, files []File) string {
+	if len(files) == 0 {
+		return "d4:name" + bencodeString(name) + "6:lengthi" + bencodeInt(length) + "ee"
+	}
+	var filesPart string
+	for _, f := range files {
+		var pathPart string
+		for _, seg := range f.Path {
+			pathPart += bencodeString(seg)
+		}
+		filesPart += "d6:lengthi" + bencodeInt(f.Length) + "e4:pathl" + pathPart + "ee"
+	}
+	return "d4:name" + bencodeString(name) + "5:filesl" + filesPart + "ee"
+}
+
+func bencodeString(s string) string {
+	return itoa(len(s)) + ":" + s
+}
+
+func bencodeInt(n int64) string {
+	return itoa(int(n))
+}
+
+func itoa(n int) string {
+	if n == 0 {
+		return "0"
+	}
+	neg := n < 0
+	if neg {
+		n = -n
+	}
+	var digits []byte
+	for n > 0 {
+		digits = append([]byte{byte('0' + n%10)}, digits...)
+		n /= 10
+	}
+	if neg {
+		return "-" + string(digits)
+	}
+	return string(digits)
+}
+
+func TestParseSingleFile(t *testing.T) {
+	info := bencodeInfoDict("movie.mkv", 1234, nil)
+	raw := "d4:info" + info + "e"
+
+	mi, err := Parse([]byte(raw))
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if mi.Name != "movie.mkv" {
+		t.Errorf("Name = %q, want %q", mi.Name, "movie.mkv")
+	}
+	if mi.TotalLength != 1234 {
+		t.Errorf("TotalLength = %d, want %d", mi.TotalLength, 1234)
+	}
+	if len(mi.Files) != 0 {
+		t.Errorf("Files = %v, want empty", mi.Files)
+	}
+	wantHash := sha1.Sum([]byte(info))
+	if mi.InfoHash != wantHash {
+		t.Errorf("InfoHash = %x, want %x", mi.InfoHash, wantHash)
+	}
+}
+
+func TestParseMultiFile(t *testing.T) {
+	files := []File{
+		{Path: []string{"disc1", "a.bin"}, Length: 100},
+		{Path: []string{"disc2", "b.bin"}, Length: 200},
+	}
+	info := bencodeInfoDict("set", 0, files)
+	raw := "d4:info" + info + "e"
+
+	mi, err := Parse([]byte(raw))
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if mi.Name != "set" {
+		t.Errorf("Name = %q, want %q", mi.Name, "set")
+	}
+	if mi.TotalLength != 300 {
+		t.Errorf("TotalLength = %d, want %d", mi.TotalLength, 300)
+	}
+	if !reflect.DeepEqual(mi.Files, files) {
+		t.Errorf("Files = %#v, want %#v", mi.Files, files)
+	}
+}
+
+func TestParseMissingInfo(t *testing.T) {
+	if _, err := Parse([]byte("de")); err == nil {
+		t.Error("Parse with no info dictionary succeeded, want error")
+	}
+}
+
+func TestParseMalformed(t *testing.T) {
+	if _, err := Parse([]byte("not bencode")); err == nil {
+		t.Error("Parse of malformed input succeeded, want error")
+	}
+}
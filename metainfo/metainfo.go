@@ -0,0 +1,117 @@
+/*
+ * go-libdeluge v0.1.0 - a native deluge RPC client library
+ * Copyright (C) 2015~2016 gdm85 - https://github.com/gdm85/go-libdeluge/
+This program is free software; you can redistribute it and/or
+modify it under the terms of the GNU General Public License
+as published by the Free Software Foundation; either version 2
+of the License, or (at your option) any later version.
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+You should have received a copy of the GNU General Public License
+along with this program; if not, write to the Free Software
+Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+*/
+
+// Package metainfo parses bencoded .torrent metainfo files into a small Go
+// struct, so that callers of Client.AddTorrentFile can validate a torrent
+// or match its hash before ever opening a connection to the daemon.
+package metainfo
+
+import (
+	"crypto/sha1"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+)
+
+// File describes one entry of a multi-file torrent.
+type File struct {
+	Path   []string
+	Length int64
+}
+
+// MetaInfo is the subset of a .torrent file's info dictionary that callers
+// typically need.
+type MetaInfo struct {
+	// InfoHash is the SHA-1 of the bencoded info dictionary, as used by
+	// Deluge (and BitTorrent generally) to identify a torrent.
+	InfoHash [20]byte
+	Name     string
+	// Files is empty for single-file torrents; use Name and TotalLength
+	// instead in that case.
+	Files       []File
+	TotalLength int64
+}
+
+// Load parses a bencoded .torrent metainfo document from r.
+func Load(r io.Reader) (*MetaInfo, error) {
+	raw, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(raw)
+}
+
+// LoadFile parses a .torrent metainfo file from disk.
+func LoadFile(path string) (*MetaInfo, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Load(f)
+}
+
+// Parse decodes raw bencoded .torrent bytes into a MetaInfo.
+func Parse(raw []byte) (*MetaInfo, error) {
+	d := &decoder{data: raw}
+	top, topRaw, err := d.decodeDict()
+	if err != nil {
+		return nil, err
+	}
+
+	infoRaw, ok := topRaw["info"]
+	if !ok {
+		return nil, errors.New("metainfo: missing info dictionary")
+	}
+	info, ok := top["info"].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("metainfo: info is not a dictionary")
+	}
+
+	mi := &MetaInfo{
+		// the info hash is the SHA-1 of the info dictionary exactly as it
+		// was bencoded, not a re-encoding of the decoded value.
+		InfoHash: sha1.Sum(infoRaw),
+	}
+	mi.Name, _ = info["name"].(string)
+
+	if filesRaw, ok := info["files"].([]interface{}); ok {
+		for _, f := range filesRaw {
+			fm, ok := f.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			length, _ := fm["length"].(int64)
+
+			var path []string
+			if segs, ok := fm["path"].([]interface{}); ok {
+				for _, s := range segs {
+					if str, ok := s.(string); ok {
+						path = append(path, str)
+					}
+				}
+			}
+
+			mi.Files = append(mi.Files, File{Path: path, Length: length})
+			mi.TotalLength += length
+		}
+	} else if length, ok := info["length"].(int64); ok {
+		mi.TotalLength = length
+	}
+
+	return mi, nil
+}
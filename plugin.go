@@ -0,0 +1,117 @@
+/*
+ * go-libdeluge v0.1.0 - a native deluge RPC client library
+ * Copyright (C) 2015~2016 gdm85 - https://github.com/gdm85/go-libdeluge/
+This program is free software; you can redistribute it and/or
+modify it under the terms of the GNU General Public License
+as published by the Free Software Foundation; either version 2
+of the License, or (at your option) any later version.
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+You should have received a copy of the GNU General Public License
+along with this program; if not, write to the Free Software
+Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+*/
+package delugeclient
+
+import (
+	"context"
+
+	"github.com/gdm85/go-rencode"
+)
+
+// Plugin is a handle to a named Deluge plugin, wrapping the generic
+// enable/disable calls every plugin supports. Plugin-specific RPCs, such as
+// those of the Blocklist plugin, are exposed by types built on top of it.
+type Plugin struct {
+	client *Client
+	name   string
+}
+
+// Plugin returns a handle to the named plugin. It does not itself check
+// that the plugin exists or is enabled; use AvailablePlugins or Enable for
+// that.
+func (c *Client) Plugin(name string) *Plugin {
+	return &Plugin{client: c, name: name}
+}
+
+// AvailablePlugins lists every plugin the daemon knows about, whether or
+// not it is currently enabled.
+func (c *Client) AvailablePlugins() ([]string, error) {
+	ctx, cancel := c.WithTimeout()
+	defer cancel()
+	return c.AvailablePluginsContext(ctx)
+}
+
+func (c *Client) AvailablePluginsContext(ctx context.Context) ([]string, error) {
+	resp, err := c.RpcContext(ctx, "daemon.get_available_plugins", rencode.List{}, rencode.Dictionary{})
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsError() {
+		return nil, RPCError{resp.String()}
+	}
+
+	var list rencode.List
+	err = resp.returnValue.Scan(&list)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]string, list.Length())
+	for i, v := range list.Values() {
+		result[i] = string(v.([]byte))
+	}
+
+	return result, nil
+}
+
+// Enable turns the plugin on for the current session.
+func (p *Plugin) Enable() error {
+	ctx, cancel := p.client.WithTimeout()
+	defer cancel()
+	return p.EnableContext(ctx)
+}
+
+func (p *Plugin) EnableContext(ctx context.Context) error {
+	var args rencode.List
+	args.Add(p.name)
+
+	resp, err := p.client.RpcContext(ctx, "core.enable_plugin", args, rencode.Dictionary{})
+	if err != nil {
+		return err
+	}
+	if resp.IsError() {
+		return RPCError{resp.String()}
+	}
+	return nil
+}
+
+// Disable turns the plugin off for the current session.
+func (p *Plugin) Disable() error {
+	ctx, cancel := p.client.WithTimeout()
+	defer cancel()
+	return p.DisableContext(ctx)
+}
+
+func (p *Plugin) DisableContext(ctx context.Context) error {
+	var args rencode.List
+	args.Add(p.name)
+
+	resp, err := p.client.RpcContext(ctx, "core.disable_plugin", args, rencode.Dictionary{})
+	if err != nil {
+		return err
+	}
+	if resp.IsError() {
+		return RPCError{resp.String()}
+	}
+	return nil
+}
+
+func toString(v interface{}) (string, bool) {
+	b, ok := v.([]byte)
+	if !ok {
+		return "", false
+	}
+	return string(b), true
+}
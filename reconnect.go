@@ -0,0 +1,205 @@
+/*
+ * go-libdeluge v0.1.0 - a native deluge RPC client library
+ * Copyright (C) 2015~2016 gdm85 - https://github.com/gdm85/go-libdeluge/
+This program is free software; you can redistribute it and/or
+modify it under the terms of the GNU General Public License
+as published by the Free Software Foundation; either version 2
+of the License, or (at your option) any later version.
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+You should have received a copy of the GNU General Public License
+along with this program; if not, write to the Free Software
+Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+*/
+package delugeclient
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"math/rand"
+	"sync/atomic"
+	"time"
+
+	"github.com/gdm85/go-rencode"
+)
+
+// ErrDisconnected is returned by a pending RPC call when the connection it
+// was issued on drops before a response arrives, instead of leaving the
+// caller to hang until its context deadline (if any) expires.
+var ErrDisconnected = errors.New("client disconnected")
+
+// ClientState is the lifecycle state of a Client supervised by Run.
+type ClientState int32
+
+const (
+	Disconnected ClientState = iota
+	Connecting
+	Ready
+	Reconnecting
+)
+
+func (s ClientState) String() string {
+	switch s {
+	case Disconnected:
+		return "Disconnected"
+	case Connecting:
+		return "Connecting"
+	case Ready:
+		return "Ready"
+	case Reconnecting:
+		return "Reconnecting"
+	default:
+		return "Unknown"
+	}
+}
+
+// closedChan is a pre-closed channel used as the initial value of
+// Client.connDone, so an RpcContext called before Connect fails fast with
+// ErrDisconnected instead of blocking forever.
+var closedChan = func() chan struct{} {
+	ch := make(chan struct{})
+	close(ch)
+	return ch
+}()
+
+const (
+	// DefaultMinBackoff is the backoff Run starts from before its first
+	// reconnect attempt; sleepBackoff actually waits a jittered value
+	// between half of it and all of it.
+	DefaultMinBackoff = 500 * time.Millisecond
+	// DefaultMaxBackoff caps how long Run's exponential backoff grows to
+	// between reconnect attempts.
+	DefaultMaxBackoff = 30 * time.Second
+)
+
+// State returns the client's current lifecycle state.
+func (c *Client) State() ClientState {
+	return ClientState(atomic.LoadInt32(&c.state))
+}
+
+// Ready returns a channel that is closed the next time the client
+// transitions to the Ready state, for callers that want to gate work on
+// connectivity. A Ready channel only fires once; call Ready() again
+// afterwards to wait for a subsequent transition (e.g. after a
+// reconnect).
+func (c *Client) Ready() <-chan struct{} {
+	c.readyMu.Lock()
+	defer c.readyMu.Unlock()
+	return c.readyCh
+}
+
+func (c *Client) setState(s ClientState) {
+	atomic.StoreInt32(&c.state, int32(s))
+	if s != Ready {
+		return
+	}
+
+	c.readyMu.Lock()
+	close(c.readyCh)
+	c.readyCh = make(chan struct{})
+	c.readyMu.Unlock()
+}
+
+func (c *Client) currentConnDone() chan struct{} {
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+	return c.connDone
+}
+
+// currentConn returns the live connection, its disconnect signal and its
+// generation number, all read under the same lock so a caller never
+// observes one field from before a reconnect and another from after it.
+func (c *Client) currentConn() (*tls.Conn, chan struct{}, uint64) {
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+	return c.conn, c.connDone, c.connGen
+}
+
+// Run supervises the connection for as long as ctx is alive: it dials, logs
+// in, replays any event subscriptions made so far via Subscribe, and on any
+// I/O error reconnects with jittered exponential backoff rather than
+// leaving the caller to rebuild everything by hand. It only returns once
+// ctx is done.
+func (c *Client) Run(ctx context.Context) error {
+	backoff := DefaultMinBackoff
+
+	for {
+		err := c.ConnectContext(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			c.setState(Reconnecting)
+			if !c.sleepBackoff(ctx, &backoff) {
+				return ctx.Err()
+			}
+			continue
+		}
+		backoff = DefaultMinBackoff
+
+		if err := c.replaySubscriptions(ctx); err != nil && c.settings.Logger != nil {
+			c.settings.Logger.Printf("failed to replay event subscriptions: %v\n", err)
+		}
+
+		select {
+		case <-c.currentConnDone():
+			// connection dropped; loop around to reconnect
+			c.setState(Reconnecting)
+		case <-ctx.Done():
+			c.Close()
+			return ctx.Err()
+		}
+	}
+}
+
+// sleepBackoff waits for a jittered fraction of backoff (to avoid many
+// clients reconnecting in lockstep), then doubles it up to
+// DefaultMaxBackoff. It returns false if ctx is done first.
+func (c *Client) sleepBackoff(ctx context.Context, backoff *time.Duration) bool {
+	jitter := time.Duration(rand.Int63n(int64(*backoff) + 1))
+	wait := *backoff/2 + jitter/2
+
+	select {
+	case <-time.After(wait):
+	case <-ctx.Done():
+		return false
+	}
+
+	*backoff *= 2
+	if *backoff > DefaultMaxBackoff {
+		*backoff = DefaultMaxBackoff
+	}
+	return true
+}
+
+// replaySubscriptions re-issues daemon.set_event_interest for every event
+// name ever passed to Subscribe, so a reconnect does not silently drop
+// events an existing Subscription is still waiting on.
+func (c *Client) replaySubscriptions(ctx context.Context) error {
+	c.subscribeMu.Lock()
+	names := make([]string, 0, len(c.subscribedNames))
+	for name := range c.subscribedNames {
+		names = append(names, name)
+	}
+	c.subscribeMu.Unlock()
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	var args rencode.List
+	args.Add(sliceToRencodeList(names))
+
+	resp, err := c.RpcContext(ctx, "daemon.set_event_interest", args, rencode.Dictionary{})
+	if err != nil {
+		return err
+	}
+	if resp.IsError() {
+		return RPCError{resp.String()}
+	}
+	return nil
+}
@@ -0,0 +1,248 @@
+/*
+ * go-libdeluge v0.1.0 - a native deluge RPC client library
+ * Copyright (C) 2015~2016 gdm85 - https://github.com/gdm85/go-libdeluge/
+This program is free software; you can redistribute it and/or
+modify it under the terms of the GNU General Public License
+as published by the Free Software Foundation; either version 2
+of the License, or (at your option) any later version.
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+You should have received a copy of the GNU General Public License
+along with this program; if not, write to the Free Software
+Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+*/
+package delugeclient
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gdm85/go-rencode"
+)
+
+// MethodStats is a point-in-time snapshot of the calls made to a single
+// RPC method.
+type MethodStats struct {
+	Calls        int64
+	TotalLatency time.Duration
+}
+
+// SessionStats is a point-in-time, race-free snapshot of wire-level client
+// health, returned by Client.Stats(). It is the client-side counterpart to
+// SessionStatus, which reports what the daemon itself is observing.
+type SessionStats struct {
+	BytesSentRPC        int64
+	BytesRecvRPC        int64
+	RPCCallsTotal       int64
+	RPCErrorsTotal      int64
+	EventsReceivedTotal int64
+	PerMethod           map[string]MethodStats
+}
+
+// clientStats is the mutable counters backing Client.Stats(); the counters
+// are updated with sync/atomic, while the perMethod map is guarded by mu,
+// which recordCall holds only long enough to look up or create a method's
+// entry.
+type clientStats struct {
+	bytesSentRPC        int64
+	bytesRecvRPC        int64
+	rpcCallsTotal       int64
+	rpcErrorsTotal      int64
+	eventsReceivedTotal int64
+
+	mu        sync.Mutex
+	perMethod map[string]*methodStats
+}
+
+type methodStats struct {
+	calls        int64
+	totalLatency int64 // time.Duration nanoseconds, accessed atomically
+}
+
+func newClientStats() *clientStats {
+	return &clientStats{perMethod: make(map[string]*methodStats)}
+}
+
+func (s *clientStats) addBytesSent(n int) {
+	atomic.AddInt64(&s.bytesSentRPC, int64(n))
+}
+
+func (s *clientStats) addBytesRecv(n int) {
+	atomic.AddInt64(&s.bytesRecvRPC, int64(n))
+}
+
+func (s *clientStats) recordEvent() {
+	atomic.AddInt64(&s.eventsReceivedTotal, 1)
+}
+
+// recordCall updates the totals and the per-method counters for one
+// completed Rpc/RpcContext call.
+func (s *clientStats) recordCall(method string, isError bool, latency time.Duration) {
+	atomic.AddInt64(&s.rpcCallsTotal, 1)
+	if isError {
+		atomic.AddInt64(&s.rpcErrorsTotal, 1)
+	}
+
+	s.mu.Lock()
+	m, ok := s.perMethod[method]
+	if !ok {
+		m = &methodStats{}
+		s.perMethod[method] = m
+	}
+	s.mu.Unlock()
+
+	atomic.AddInt64(&m.calls, 1)
+	atomic.AddInt64(&m.totalLatency, int64(latency))
+}
+
+func (s *clientStats) snapshot() SessionStats {
+	s.mu.Lock()
+	perMethod := make(map[string]MethodStats, len(s.perMethod))
+	for name, m := range s.perMethod {
+		perMethod[name] = MethodStats{
+			Calls:        atomic.LoadInt64(&m.calls),
+			TotalLatency: time.Duration(atomic.LoadInt64(&m.totalLatency)),
+		}
+	}
+	s.mu.Unlock()
+
+	return SessionStats{
+		BytesSentRPC:        atomic.LoadInt64(&s.bytesSentRPC),
+		BytesRecvRPC:        atomic.LoadInt64(&s.bytesRecvRPC),
+		RPCCallsTotal:       atomic.LoadInt64(&s.rpcCallsTotal),
+		RPCErrorsTotal:      atomic.LoadInt64(&s.rpcErrorsTotal),
+		EventsReceivedTotal: atomic.LoadInt64(&s.eventsReceivedTotal),
+		PerMethod:           perMethod,
+	}
+}
+
+func (s *clientStats) reset() {
+	atomic.StoreInt64(&s.bytesSentRPC, 0)
+	atomic.StoreInt64(&s.bytesRecvRPC, 0)
+	atomic.StoreInt64(&s.rpcCallsTotal, 0)
+	atomic.StoreInt64(&s.rpcErrorsTotal, 0)
+	atomic.StoreInt64(&s.eventsReceivedTotal, 0)
+
+	s.mu.Lock()
+	s.perMethod = make(map[string]*methodStats)
+	s.mu.Unlock()
+}
+
+// Stats returns a snapshot of this client's wire-level counters: bytes
+// moved, calls made, errors seen and events delivered.
+func (c *Client) Stats() SessionStats {
+	return c.stats.snapshot()
+}
+
+// ResetStats zeroes every counter tracked by Stats.
+func (c *Client) ResetStats() {
+	c.stats.reset()
+}
+
+// sessionStatusKeys are the core.get_session_status keys fetched by
+// GetSessionStatus.
+var sessionStatusKeys = []string{
+	"payload_upload_rate",
+	"payload_download_rate",
+	"total_upload",
+	"total_download",
+	"dht_nodes",
+	"num_peers",
+}
+
+// SessionStatus holds daemon-side session counters, fetched via
+// core.get_session_status. It is the daemon-side counterpart to
+// SessionStats, which reports wire-level client health.
+type SessionStatus struct {
+	PayloadUploadRate   float64
+	PayloadDownloadRate float64
+	TotalUpload         int64
+	TotalDownload       int64
+	DHTNodes            int64
+	NumPeers            int64
+}
+
+// GetSessionStatus fetches a fixed set of session counters from the
+// daemon's core.get_session_status.
+func (c *Client) GetSessionStatus() (SessionStatus, error) {
+	ctx, cancel := c.WithTimeout()
+	defer cancel()
+	return c.GetSessionStatusContext(ctx)
+}
+
+func (c *Client) GetSessionStatusContext(ctx context.Context) (SessionStatus, error) {
+	var args rencode.List
+	args.Add(sliceToRencodeList(sessionStatusKeys))
+
+	resp, err := c.RpcContext(ctx, "core.get_session_status", args, rencode.Dictionary{})
+	if err != nil {
+		return SessionStatus{}, err
+	}
+	if resp.IsError() {
+		return SessionStatus{}, RPCError{resp.String()}
+	}
+
+	var statusDict rencode.Dictionary
+	err = resp.returnValue.Scan(&statusDict)
+	if err != nil {
+		return SessionStatus{}, err
+	}
+
+	var status SessionStatus
+	for i := 0; i < statusDict.Length(); i++ {
+		key := string(statusDict.Keys()[i].([]byte))
+		value := statusDict.Values()[i]
+		switch key {
+		case "payload_upload_rate":
+			status.PayloadUploadRate, _ = toFloat64(value)
+		case "payload_download_rate":
+			status.PayloadDownloadRate, _ = toFloat64(value)
+		case "total_upload":
+			status.TotalUpload, _ = toInt64(value)
+		case "total_download":
+			status.TotalDownload, _ = toInt64(value)
+		case "dht_nodes":
+			status.DHTNodes, _ = toInt64(value)
+		case "num_peers":
+			status.NumPeers, _ = toInt64(value)
+		}
+	}
+
+	return status, nil
+}
+
+// toFloat64 and toInt64 convert the numeric types rencode may produce
+// (ints, floats, of various widths) into a single Go type, since the
+// daemon does not commit to one wire representation per field.
+
+func toFloat64(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	default:
+		return 0, false
+	}
+}
+
+func toInt64(v interface{}) (int64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return n, true
+	case int:
+		return int64(n), true
+	case float64:
+		return int64(n), true
+	default:
+		return 0, false
+	}
+}